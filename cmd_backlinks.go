@@ -0,0 +1,56 @@
+package notes
+
+import (
+	"io"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// BacklinksCmd represents `notes backlinks` command. Each public field represents options
+// of the command. Out field represents where this command should output.
+type BacklinksCmd struct {
+	cli    *kingpin.CmdClause
+	Config *Config
+	// Path is the note to print the backlinks of
+	Path string
+	// Relative is a flag equivalent to --relative
+	Relative bool
+	// SortBy is a string indicating how to sort the list. This value is equivalent to --sort option
+	SortBy string
+	// Edit is a flag equivalent to --edit
+	Edit bool
+	// Out is a writer to write output of this command. Kind of stdout is expected
+	Out io.Writer
+}
+
+func (cmd *BacklinksCmd) defineCLI(app *kingpin.Application) {
+	cmd.cli = app.Command("backlinks", "Show every note that links to a note")
+	cmd.cli.Arg("path", "Path of the note to show backlinks of").Required().StringVar(&cmd.Path)
+	defineListOutputFlags(cmd.cli, &cmd.Relative, &cmd.SortBy, &cmd.Edit)
+}
+
+func (cmd *BacklinksCmd) matchesCmdline(cmdline string) bool {
+	return cmd.cli.FullCommand() == cmdline
+}
+
+// Do runs `notes backlinks` command and returns an error if occurs
+func (cmd *BacklinksCmd) Do() error {
+	rel, err := relNotePath(cmd.Config, cmd.Path)
+	if err != nil {
+		return err
+	}
+
+	sources, err := inboundLinks(cmd.Config, rel)
+	if err != nil {
+		return err
+	}
+
+	notes, err := loadNotesByPath(cmd.Config, sources)
+	if err != nil {
+		return err
+	}
+
+	return runWithPager(cmd.Config, cmd.Out, notes, func(w io.Writer, notes []*Note) error {
+		return printNoteList(cmd.Config, w, notes, cmd.SortBy, cmd.Edit, cmd.Relative)
+	})
+}
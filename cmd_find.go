@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"gopkg.in/alecthomas/kingpin.v2"
@@ -15,24 +16,63 @@ type FindCmd struct {
 	cli    *kingpin.CmdClause
 	out    io.Writer
 	Config *Config
-	// Query is a query string for searching notes
+	// Query is a query string for searching notes. It is a shortcut for --match: when
+	// --match is not given, Query is used as the match text instead
 	Query string
+	// Match is a flag equivalent to --match. It is the full-text portion of the query,
+	// searched across title, tags and body text
+	Match string
+	// Tags is a flag equivalent to repeated --tag. A note must carry every one of these
+	// (normalized) tags to match
+	Tags []string
+	// AnyTag is a flag equivalent to --any-tag. It takes a comma-separated list of tags and
+	// a note matches when it carries at least one of them
+	AnyTag string
+	// Category is a flag equivalent to --category. A note matches when its category equals
+	// it, or is nested under it
+	Category string
+	// Created is a flag equivalent to --created. It is a date range, either
+	// 'FROM..TO' or a relative '<DURATION' such as '<7d'
+	Created string
+	// Modified is a flag equivalent to --modified. It accepts the same range syntax as
+	// Created, applied to each note's modified time instead
+	Modified string
 	// Relative is a flag equivalent to --relative
 	Relative bool
 	// SortBy is a string indicating how to sort the list. This value is equivalent to --sort option
 	SortBy string
 	// Edit is a flag equivalent to --edit
 	Edit bool
+	// Snippet is a flag equivalent to --snippet. It shows a highlighted excerpt of each
+	// match below its oneline entry, rendered by the SQLite index's snippet() function
+	Snippet bool
+	// LinkedTo is a flag equivalent to --linked-to. It restricts results to notes that
+	// link to the given note path
+	LinkedTo string
+	// LinkedBy is a flag equivalent to --linked-by. It restricts results to notes linked
+	// to by the given note path
+	LinkedBy string
 	// Out is a writer to write output of this command. Kind of stdout is expected
 	Out io.Writer
+
+	snippets map[string]string
 }
 
 func (cmd *FindCmd) defineCLI(app *kingpin.Application) {
-	cmd.cli = app.Command("find", "Find notes by query in title, tags, metadata and body text")
-	cmd.cli.Arg("query", "Query string to search in notes").Required().StringVar(&cmd.Query)
+	cmd.cli = app.Command("find", "Find notes by structured filters and/or free-text query")
+	cmd.cli.Arg("query", "Shortcut for --match").StringVar(&cmd.Query)
+	cmd.cli.Flag("match", "Query string to search in title, tags and body text").StringVar(&cmd.Match)
+	cmd.cli.Flag("tag", "Only show notes with this tag. Repeat to require multiple tags").StringsVar(&cmd.Tags)
+	cmd.cli.Flag("any-tag", "Only show notes with at least one of these comma-separated tags").StringVar(&cmd.AnyTag)
+	cmd.cli.Flag("category", "Only show notes under this category").StringVar(&cmd.Category)
+	cmd.cli.Flag("created", "Only show notes created in this range: 'FROM..TO' or '<7d'").StringVar(&cmd.Created)
+	cmd.cli.Flag("modified", "Only show notes modified in this range: 'FROM..TO' or '<7d'").StringVar(&cmd.Modified)
 	cmd.cli.Flag("relative", "Show relative paths from $NOTES_HOME directory").Short('r').BoolVar(&cmd.Relative)
 	cmd.cli.Flag("sort", "Sort list by 'modified', 'created', 'filename' or 'category'. Default is 'created'").Short('s').EnumVar(&cmd.SortBy, "modified", "created", "filename", "category")
 	cmd.cli.Flag("edit", "Open listed notes with your favorite editor. $NOTES_EDITOR must be set. Paths of listed notes are passed to the editor command's arguments").Short('e').BoolVar(&cmd.Edit)
+	cmd.cli.Flag("snippet", "Show a highlighted excerpt of the matched text below each note").BoolVar(&cmd.Snippet)
+	cmd.cli.Flag("linked-to", "Only show notes that link to the given note path").StringVar(&cmd.LinkedTo)
+	cmd.cli.Flag("linked-by", "Only show notes linked to by the given note path").StringVar(&cmd.LinkedBy)
 }
 
 func (cmd *FindCmd) matchesCmdline(cmdline string) bool {
@@ -71,40 +111,56 @@ func (cmd *FindCmd) printNotes(notes []*Note) error {
 		return err
 	}
 
-	return printOnelineNotesTo(cmd.out, notes)
+	if len(cmd.snippets) == 0 {
+		return printOnelineNotesTo(cmd.out, notes)
+	}
+
+	var list bytes.Buffer
+	if err := printOnelineNotesTo(&list, notes); err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	for i, line := range strings.SplitAfter(list.String(), "\n") {
+		b.WriteString(line)
+		if i >= len(notes) {
+			continue
+		}
+		snippet, ok := cmd.snippets[notes[i].RelFilePath()]
+		if !ok || snippet == "" {
+			continue
+		}
+		b.WriteString("    ")
+		b.WriteString(snippet)
+		b.WriteRune('\n')
+	}
+	_, err := cmd.out.Write(b.Bytes())
+	return err
 }
 
 // Do runs `notes find` command and returns an error if occurs
 func (cmd *FindCmd) Do() error {
-	cats, err := CollectCategories(cmd.Config, 0)
+	query := strings.ToLower(strings.TrimSpace(cmd.effectiveMatch()))
+
+	notes, snippets, err := cmd.searchViaIndex(query)
 	if err != nil {
-		return err
+		notes, err = cmd.scanForQuery(query)
+		if err != nil {
+			return err
+		}
 	}
+	cmd.snippets = snippets
 
-	query := strings.ToLower(strings.TrimSpace(cmd.Query))
-
-	numNotes := 0
-	for _, c := range cats {
-		numNotes += len(c.NotePaths)
+	notes, err = cmd.applyLinkFilters(notes)
+	if err != nil {
+		return err
 	}
 
-	notes := make([]*Note, 0, numNotes)
-	for _, cat := range cats {
-		for _, p := range cat.NotePaths {
-			note, err := LoadNote(p, cmd.Config)
-			if err != nil {
-				return err
-			}
-			searchable, err := note.SearchableText()
-			if err != nil {
-				return err
-			}
-			if !findQueryMatch(searchable, query) {
-				continue
-			}
-			notes = append(notes, note)
-		}
+	filter, err := cmd.buildFilters()
+	if err != nil {
+		return err
 	}
+	notes = filterNotes(notes, filter)
 
 	if len(notes) == 0 {
 		return nil
@@ -132,6 +188,140 @@ func (cmd *FindCmd) Do() error {
 	return errors.Wrap(pager.Err, "Pager command did not run successfully")
 }
 
+// effectiveMatch returns the full-text portion of the search: --match if given, otherwise
+// the positional query argument as a shortcut for it.
+func (cmd *FindCmd) effectiveMatch() string {
+	if cmd.Match != "" {
+		return cmd.Match
+	}
+	return cmd.Query
+}
+
+// buildFilters composes a NoteFilter out of --tag/--any-tag/--category/--created/--modified,
+// or nil when none of them were given.
+func (cmd *FindCmd) buildFilters() (NoteFilter, error) {
+	var filters andFilters
+
+	if len(cmd.Tags) > 0 {
+		filters = append(filters, allTagsFilter{Tags: cmd.Tags})
+	}
+	if cmd.AnyTag != "" {
+		filters = append(filters, anyTagFilter{Tags: strings.Split(cmd.AnyTag, ",")})
+	}
+	if cmd.Category != "" {
+		filters = append(filters, categoryFilter{Category: cmd.Category})
+	}
+	if cmd.Created != "" {
+		from, to, err := parseDateRange(cmd.Created, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, dateRangeFilter{Field: func(n *Note) time.Time { return n.Created }, From: from, To: to})
+	}
+	if cmd.Modified != "" {
+		from, to, err := parseDateRange(cmd.Modified, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, dateRangeFilter{Field: func(n *Note) time.Time { return n.Modified }, From: from, To: to})
+	}
+
+	if len(filters) == 0 {
+		return nil, nil
+	}
+	return filters, nil
+}
+
+// applyLinkFilters narrows notes down to those matching --linked-to/--linked-by, if set.
+func (cmd *FindCmd) applyLinkFilters(notes []*Note) ([]*Note, error) {
+	if cmd.LinkedTo == "" && cmd.LinkedBy == "" {
+		return notes, nil
+	}
+
+	allowed := map[string]bool{}
+	first := true
+
+	if cmd.LinkedTo != "" {
+		rel, err := relNotePath(cmd.Config, cmd.LinkedTo)
+		if err != nil {
+			return nil, err
+		}
+		sources, err := inboundLinks(cmd.Config, rel)
+		if err != nil {
+			return nil, err
+		}
+		intersectAllowed(&allowed, &first, sources)
+	}
+
+	if cmd.LinkedBy != "" {
+		rel, err := relNotePath(cmd.Config, cmd.LinkedBy)
+		if err != nil {
+			return nil, err
+		}
+		targets, err := outboundLinks(cmd.Config, rel)
+		if err != nil {
+			return nil, err
+		}
+		intersectAllowed(&allowed, &first, targets)
+	}
+
+	filtered := make([]*Note, 0, len(notes))
+	for _, n := range notes {
+		if allowed[n.RelFilePath()] {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered, nil
+}
+
+// intersectAllowed narrows *allowed down to its intersection with paths. On the first
+// call (first == true) it simply seeds *allowed with paths.
+func intersectAllowed(allowed *map[string]bool, first *bool, paths []string) {
+	next := map[string]bool{}
+	for _, p := range paths {
+		if *first || (*allowed)[p] {
+			next[p] = true
+		}
+	}
+	*allowed = next
+	*first = false
+}
+
+// scanForQuery is the original full-scan search path: it loads every note and matches its
+// searchable text against query. It is used as a fallback whenever the SQLite FTS5 index
+// is unavailable, e.g. in a `nosqlite` build.
+func (cmd *FindCmd) scanForQuery(query string) ([]*Note, error) {
+	cats, err := CollectCategories(cmd.Config, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	numNotes := 0
+	for _, c := range cats {
+		numNotes += len(c.NotePaths)
+	}
+
+	notes := make([]*Note, 0, numNotes)
+	for _, cat := range cats {
+		for _, p := range cat.NotePaths {
+			note, err := LoadNote(p, cmd.Config)
+			if err != nil {
+				return nil, err
+			}
+			searchable, err := noteSearchText(note)
+			if err != nil {
+				return nil, err
+			}
+			if !findQueryMatch(searchable, query) {
+				continue
+			}
+			notes = append(notes, note)
+		}
+	}
+
+	return notes, nil
+}
+
 func findQueryMatch(text, query string) bool {
 	query = strings.ToLower(strings.TrimSpace(query))
 	if query == "" {
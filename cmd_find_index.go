@@ -0,0 +1,222 @@
+//go:build !nosqlite
+// +build !nosqlite
+
+package notes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/naterator/notes/index"
+	"github.com/naterator/notes/links"
+	"github.com/pkg/errors"
+)
+
+// defaultSearchLimit caps how many rows a single `find`/`list` invocation pulls from the
+// index. Matches past this cap are not returned; the full-scan fallback has no such cap.
+const defaultSearchLimit = 1000
+
+func indexDBPath(cfg *Config) string {
+	return filepath.Join(cfg.HomePath, ".notes", "index.db")
+}
+
+// searchViaIndex serves a `find` query from the persistent SQLite FTS5 index, re-indexing
+// any note whose size or checksum has drifted since the last run. It returns the matching
+// notes in ranked order and, when cmd.Snippet is set, a path -> rendered snippet map.
+func (cmd *FindCmd) searchViaIndex(query string) (matched []*Note, snippets map[string]string, err error) {
+	idx, err := index.Open(indexDBPath(cmd.Config))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer idx.Close()
+
+	if err := syncIndex(idx, cmd.Config); err != nil {
+		return nil, nil, err
+	}
+
+	results, err := idx.Search(query, defaultSearchLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matched = make([]*Note, 0, len(results))
+	if cmd.Snippet {
+		snippets = make(map[string]string, len(results))
+	}
+	for _, r := range results {
+		n, err := LoadNote(filepath.Join(cmd.Config.HomePath, r.Path), cmd.Config)
+		if err != nil {
+			return nil, nil, err
+		}
+		matched = append(matched, n)
+		if cmd.Snippet {
+			snippets[r.Path] = r.Snippet
+		}
+	}
+	return matched, snippets, nil
+}
+
+// openIndexIfAvailable opens the index. In this build it returns index.Open's result
+// unchanged, so an index that cannot be opened is reported as an error; only the
+// `nosqlite` build returns (nil, nil).
+func openIndexIfAvailable(cfg *Config) (*index.Index, error) {
+	return index.Open(indexDBPath(cfg))
+}
+
+// rebuildIndex drops and repopulates the whole index, used by `notes reindex`.
+func rebuildIndex(cfg *Config) error {
+	idx, err := index.Open(indexDBPath(cfg))
+	if err != nil {
+		return err
+	}
+	defer idx.Close()
+
+	if err := idx.Reset(); err != nil {
+		return err
+	}
+
+	return syncIndex(idx, cfg)
+}
+
+// noteInfo is a stat'd note path, gathered up front so syncIndex can build the full
+// candidate set (needed to resolve links) before deciding which notes to re-index.
+type noteInfo struct {
+	cat  *Category
+	path string
+	full string
+	info os.FileInfo
+}
+
+// syncIndex walks the notes tree and re-indexes any file whose size or checksum differs
+// from what is currently stored, so repeated `find`/`list` invocations only pay the cost
+// of reading files that actually changed. Every note's links are re-resolved against the
+// full candidate set whenever its own content changed.
+func syncIndex(idx *index.Index, cfg *Config) error {
+	cats, err := CollectCategories(cfg, 0)
+	if err != nil {
+		return err
+	}
+
+	var all []noteInfo
+	for _, cat := range cats {
+		for _, p := range cat.NotePaths {
+			full := filepath.Join(cfg.HomePath, p)
+			info, err := os.Stat(full)
+			if err != nil {
+				return errors.Wrapf(err, "Cannot stat note %q", p)
+			}
+			all = append(all, noteInfo{cat, p, full, info})
+		}
+	}
+
+	candidates := make([]links.Candidate, 0, len(all))
+	for _, n := range all {
+		note, err := LoadNote(n.full, cfg)
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, links.Candidate{Path: n.path, Title: note.Title})
+	}
+
+	for _, n := range all {
+		_, storedSize, storedSum, ok, err := idx.Stat(n.path)
+		if err != nil {
+			return err
+		}
+
+		dirty := true
+		if ok && storedSize == n.info.Size() {
+			sum, err := checksumFile(n.full)
+			if err != nil {
+				return err
+			}
+			dirty = sum != storedSum
+		}
+		if !dirty {
+			continue
+		}
+
+		if err := reindexNote(idx, cfg, n.cat, n.path, n.full, n.info, candidates); err != nil {
+			return err
+		}
+	}
+
+	return pruneDeleted(idx, all)
+}
+
+// pruneDeleted removes indexed rows whose note is no longer among onDisk, e.g. because it
+// was deleted since the last sync. Without this, Search keeps returning stale rows for
+// files that no longer exist.
+func pruneDeleted(idx *index.Index, onDisk []noteInfo) error {
+	present := make(map[string]bool, len(onDisk))
+	for _, n := range onDisk {
+		present[n.path] = true
+	}
+
+	indexed, err := idx.Paths()
+	if err != nil {
+		return err
+	}
+
+	for _, p := range indexed {
+		if present[p] {
+			continue
+		}
+		if err := idx.Remove(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func reindexNote(idx *index.Index, cfg *Config, cat *Category, relPath, fullPath string, info os.FileInfo, candidates []links.Candidate) error {
+	note, err := LoadNote(fullPath, cfg)
+	if err != nil {
+		return err
+	}
+
+	body, err := noteBody(fullPath)
+	if err != nil {
+		return err
+	}
+
+	sum, err := checksumFile(fullPath)
+	if err != nil {
+		return err
+	}
+
+	if err := idx.Upsert(index.Record{
+		Path:     relPath,
+		Category: cat.Name,
+		Tags:     note.Tags,
+		Title:    note.Title,
+		Body:     body,
+		Created:  note.Created,
+		Modified: info.ModTime(),
+		Size:     info.Size(),
+		Checksum: sum,
+	}); err != nil {
+		return err
+	}
+
+	targets := links.ExtractTargets(body)
+	resolved := make([]string, 0, len(targets))
+	for _, t := range targets {
+		if p, ok := links.Resolve(t, candidates); ok {
+			resolved = append(resolved, p)
+		}
+	}
+	return idx.SetLinks(relPath, resolved)
+}
+
+func checksumFile(path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "Cannot read note %q for checksum", path)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
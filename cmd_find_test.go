@@ -40,14 +40,12 @@ func TestFindCmd(t *testing.T) {
 
 	for _, tc := range []struct {
 		what      string
-		title     string
-		within    string
+		match     string
 		wantPaths []string
 	}{
 		{
-			what:   "title only with case-insensitive search",
-			title:  "THIS IS TITLE",
-			within: "",
+			what:  "title only with case-insensitive search",
+			match: "THIS IS TITLE",
 			wantPaths: []string{
 				"c/3.md",
 				"b/2.md",
@@ -57,51 +55,38 @@ func TestFindCmd(t *testing.T) {
 			},
 		},
 		{
-			what:   "title and body",
-			title:  "title",
-			within: "gubergren",
+			what:  "title and body",
+			match: "title gubergren",
 			wantPaths: []string{
 				"b/2.md",
 			},
 		},
 		{
-			what:   "title and body case-insensitive",
-			title:  "title",
-			within: "GUBERGREN",
+			what:  "title and body case-insensitive",
+			match: "title GUBERGREN",
 			wantPaths: []string{
 				"b/2.md",
 			},
 		},
 		{
-			what:   "title and metadata tags",
-			title:  "title",
-			within: "A-BIT-LONG",
+			what:  "title and tags",
+			match: "title A-BIT-LONG",
 			wantPaths: []string{
 				"c/5.md",
 			},
 		},
-		{
-			what:   "title and metadata created",
-			title:  "text from",
-			within: "2118-10-30",
-			wantPaths: []string{
-				"b/6.md",
-			},
-		},
 		{
 			what:      "no match",
-			title:     "no-matching-title",
-			within:    "",
+			match:     "no-matching-title",
 			wantPaths: nil,
 		},
 	} {
 		t.Run(tc.what, func(t *testing.T) {
 			var buf bytes.Buffer
 			cmd := &FindCmd{
-				Config:      cfg,
-				Out:         &buf,
-				TitleQuery:  tc.title,
-				WithinQuery: tc.within,
+				Config: cfg,
+				Out:    &buf,
+				Match:  tc.match,
 			}
 
 			if err := cmd.Do(); err != nil {
@@ -122,14 +107,109 @@ func TestFindCmd(t *testing.T) {
 	}
 }
 
+// TestFindCmdBareNumberDoesNotMatchCreatedDate guards against the historical bug where a
+// query like "2118" matched notes solely because that string happened to appear in a
+// created-date field: full-text matching only considers title, tags and body now.
+func TestFindCmdBareNumberDoesNotMatchCreatedDate(t *testing.T) {
+	cfg := testNewConfigForListCmd("normal")
+	var buf bytes.Buffer
+	cmd := &FindCmd{Config: cfg, Out: &buf, Match: "2118-10-30"}
+
+	if err := cmd.Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	if have := outputLines(buf.String()); have != nil {
+		t.Fatalf("Expected no match for a bare created-date string but have %v", have)
+	}
+}
+
+func TestFindCmdCreatedRange(t *testing.T) {
+	cfg := testNewConfigForListCmd("normal")
+	var buf bytes.Buffer
+	cmd := &FindCmd{Config: cfg, Out: &buf, Created: "2118-10-29..2118-10-31"}
+
+	if err := cmd.Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	have := firstFields(outputLines(buf.String()))
+	want := []string{filepath.FromSlash("b/6.md")}
+	if !reflect.DeepEqual(want, have) {
+		t.Fatalf("Expected paths %v but have %v", want, have)
+	}
+}
+
+func TestFindCmdTagFilter(t *testing.T) {
+	cfg := testNewConfigForListCmd("normal")
+	var buf bytes.Buffer
+	cmd := &FindCmd{Config: cfg, Out: &buf, Match: "this is title", Tags: []string{"a-bit-long"}}
+
+	if err := cmd.Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	have := firstFields(outputLines(buf.String()))
+	want := []string{filepath.FromSlash("c/5.md")}
+	if !reflect.DeepEqual(want, have) {
+		t.Fatalf("Expected paths %v but have %v", want, have)
+	}
+}
+
+func TestFindCmdAnyTagFilter(t *testing.T) {
+	cfg := testNewConfigForListCmd("normal")
+	var buf bytes.Buffer
+	cmd := &FindCmd{Config: cfg, Out: &buf, Match: "this is title", AnyTag: "a-bit-long,some-other-tag"}
+
+	if err := cmd.Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	have := firstFields(outputLines(buf.String()))
+	want := []string{filepath.FromSlash("c/5.md")}
+	if !reflect.DeepEqual(want, have) {
+		t.Fatalf("Expected paths %v but have %v", want, have)
+	}
+}
+
+func TestFindCmdCategoryFilter(t *testing.T) {
+	cfg := testNewConfigForListCmd("normal")
+	var buf bytes.Buffer
+	cmd := &FindCmd{Config: cfg, Out: &buf, Match: "this is title", Category: "c"}
+
+	if err := cmd.Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	have := firstFields(outputLines(buf.String()))
+	want := []string{filepath.FromSlash("c/3.md"), filepath.FromSlash("c/5.md")}
+	if !reflect.DeepEqual(want, have) {
+		t.Fatalf("Expected paths %v but have %v", want, have)
+	}
+}
+
+func TestFindCmdQueryIsShortcutForMatch(t *testing.T) {
+	cfg := testNewConfigForListCmd("normal")
+	var buf bytes.Buffer
+	cmd := &FindCmd{Config: cfg, Out: &buf, Query: "this is title"}
+
+	if err := cmd.Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	if have := outputLines(buf.String()); len(have) == 0 {
+		t.Fatal("Expected the positional query to behave like --match")
+	}
+}
+
 func TestFindRelative(t *testing.T) {
 	cfg := testNewConfigForListCmd("normal")
 	var buf bytes.Buffer
 	cmd := &FindCmd{
-		Config:     cfg,
-		Out:        &buf,
-		TitleQuery: "this is title",
-		Relative:   true,
+		Config:   cfg,
+		Out:      &buf,
+		Query:    "this is title",
+		Relative: true,
 	}
 
 	if err := cmd.Do(); err != nil {
@@ -153,10 +233,10 @@ func TestFindSortByFilename(t *testing.T) {
 	cfg := testNewConfigForListCmd("normal")
 	var buf bytes.Buffer
 	cmd := &FindCmd{
-		Config:     cfg,
-		Out:        &buf,
-		TitleQuery: "this is title",
-		SortBy:     "filename",
+		Config: cfg,
+		Out:    &buf,
+		Query:  "this is title",
+		SortBy: "filename",
 	}
 
 	if err := cmd.Do(); err != nil {
@@ -190,10 +270,10 @@ func TestFindCmdEditOption(t *testing.T) {
 
 	var buf bytes.Buffer
 	cmd := &FindCmd{
-		Config:     cfg,
-		Out:        &buf,
-		TitleQuery: "this is title",
-		Edit:       true,
+		Config: cfg,
+		Out:    &buf,
+		Query:  "this is title",
+		Edit:   true,
 	}
 
 	if err := cmd.Do(); err != nil {
@@ -229,9 +309,9 @@ func TestFindCmdEditOption(t *testing.T) {
 func TestFindWriteError(t *testing.T) {
 	cfg := testNewConfigForListCmd("normal")
 	cmd := &FindCmd{
-		Config:     cfg,
-		Out:        alwaysErrorWriter{},
-		TitleQuery: "title",
+		Config: cfg,
+		Out:    alwaysErrorWriter{},
+		Query:  "title",
 	}
 	if err := cmd.Do(); err == nil || !strings.Contains(err.Error(), "Write error for test") {
 		t.Fatal("Unexpected error", err)
@@ -240,7 +320,7 @@ func TestFindWriteError(t *testing.T) {
 
 func TestFindNoHome(t *testing.T) {
 	cfg := &Config{HomePath: "/path/to/unknown/directory"}
-	err := (&FindCmd{Config: cfg, TitleQuery: "title"}).Do()
+	err := (&FindCmd{Config: cfg, Query: "title"}).Do()
 	if err == nil {
 		t.Fatal("Error did not occur")
 	}
@@ -252,8 +332,8 @@ func TestFindNoHome(t *testing.T) {
 func TestFindBrokenNote(t *testing.T) {
 	cfg := testNewConfigForListCmd("fail")
 	cmd := &FindCmd{
-		Config:     cfg,
-		TitleQuery: "title",
+		Config: cfg,
+		Query:  "title",
 	}
 	err := cmd.Do()
 	if err == nil {
@@ -274,9 +354,9 @@ func TestFindPagingWithPager(t *testing.T) {
 	cfg := testNewConfigForListCmd("normal")
 	cfg.PagerCmd = "cat"
 	cmd := &FindCmd{
-		Config:     cfg,
-		Out:        &buf,
-		TitleQuery: "title",
+		Config: cfg,
+		Out:    &buf,
+		Query:  "title",
 	}
 
 	if err := cmd.Do(); err != nil {
@@ -322,9 +402,9 @@ func TestFindPagingError(t *testing.T) {
 			}
 
 			cmd := &FindCmd{
-				Config:     cfg,
-				Out:        out,
-				TitleQuery: "title",
+				Config: cfg,
+				Out:    out,
+				Query:  "title",
 			}
 
 			if err := cmd.Do(); err == nil || !strings.Contains(err.Error(), tc.want) {
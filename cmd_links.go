@@ -0,0 +1,87 @@
+package notes
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// LinksCmd represents `notes links` command. Each public field represents options of the
+// command. Out field represents where this command should output.
+type LinksCmd struct {
+	cli    *kingpin.CmdClause
+	Config *Config
+	// Path is the note to print outbound links of. Ignored when Broken is set
+	Path string
+	// Broken is a flag equivalent to --broken. It scans the whole tree for links that do
+	// not resolve to any note instead of printing the links of Path
+	Broken bool
+	// Relative is a flag equivalent to --relative
+	Relative bool
+	// SortBy is a string indicating how to sort the list. This value is equivalent to --sort option
+	SortBy string
+	// Edit is a flag equivalent to --edit
+	Edit bool
+	// Out is a writer to write output of this command. Kind of stdout is expected
+	Out io.Writer
+}
+
+func (cmd *LinksCmd) defineCLI(app *kingpin.Application) {
+	cmd.cli = app.Command("links", "Show outbound links of a note")
+	cmd.cli.Arg("path", "Path of the note to show outbound links of").StringVar(&cmd.Path)
+	cmd.cli.Flag("broken", "List every link across the tree that does not resolve to a note").BoolVar(&cmd.Broken)
+	defineListOutputFlags(cmd.cli, &cmd.Relative, &cmd.SortBy, &cmd.Edit)
+}
+
+func (cmd *LinksCmd) matchesCmdline(cmdline string) bool {
+	return cmd.cli.FullCommand() == cmdline
+}
+
+// Do runs `notes links` command and returns an error if occurs
+func (cmd *LinksCmd) Do() error {
+	if cmd.Broken {
+		return cmd.doBroken()
+	}
+
+	if cmd.Path == "" {
+		return errors.New("'path' argument is required unless --broken is given")
+	}
+
+	rel, err := relNotePath(cmd.Config, cmd.Path)
+	if err != nil {
+		return err
+	}
+
+	targets, err := outboundLinks(cmd.Config, rel)
+	if err != nil {
+		return err
+	}
+
+	notes, err := loadNotesByPath(cmd.Config, targets)
+	if err != nil {
+		return err
+	}
+
+	return runWithPager(cmd.Config, cmd.Out, notes, func(w io.Writer, notes []*Note) error {
+		return printNoteList(cmd.Config, w, notes, cmd.SortBy, cmd.Edit, cmd.Relative)
+	})
+}
+
+func (cmd *LinksCmd) doBroken() error {
+	broken, err := brokenLinks(cmd.Config)
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	for _, l := range broken {
+		b.WriteString(l.From)
+		b.WriteString(" -> ")
+		b.WriteString(l.Target)
+		b.WriteRune('\n')
+	}
+	_, err = cmd.Out.Write(b.Bytes())
+	return err
+}
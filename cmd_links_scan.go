@@ -0,0 +1,91 @@
+package notes
+
+import (
+	"path/filepath"
+
+	"github.com/naterator/notes/links"
+)
+
+// scanCandidates loads every note's path and title, used to resolve link targets when the
+// SQLite index is unavailable.
+func scanCandidates(cfg *Config) ([]links.Candidate, error) {
+	cats, err := CollectCategories(cfg, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []links.Candidate
+	for _, cat := range cats {
+		for _, p := range cat.NotePaths {
+			note, err := LoadNote(p, cfg)
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, links.Candidate{Path: p, Title: note.Title})
+		}
+	}
+	return candidates, nil
+}
+
+// scanOutbound resolves relPath's outbound link targets by reading its body directly,
+// used when the SQLite index is unavailable.
+func scanOutbound(cfg *Config, relPath string) ([]string, error) {
+	candidates, err := scanCandidates(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := readNoteBody(cfg, relPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveTargets(body, candidates), nil
+}
+
+// scanInbound finds every note whose body links to relPath, used when the SQLite index is
+// unavailable.
+func scanInbound(cfg *Config, relPath string) ([]string, error) {
+	cats, err := CollectCategories(cfg, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := scanCandidates(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var in []string
+	for _, cat := range cats {
+		for _, p := range cat.NotePaths {
+			body, err := readNoteBody(cfg, p)
+			if err != nil {
+				return nil, err
+			}
+			for _, to := range resolveTargets(body, candidates) {
+				if to == relPath {
+					in = append(in, p)
+					break
+				}
+			}
+		}
+	}
+	return in, nil
+}
+
+func resolveTargets(body string, candidates []links.Candidate) []string {
+	var resolved []string
+	for _, t := range links.ExtractTargets(body) {
+		if p, ok := links.Resolve(t, candidates); ok {
+			resolved = append(resolved, p)
+		}
+	}
+	return resolved
+}
+
+// readNoteBody returns relPath's body with front-matter stripped, matching what the
+// SQLite index indexes, so link extraction agrees whether or not the index is in use.
+func readNoteBody(cfg *Config, relPath string) (string, error) {
+	return noteBody(filepath.Join(cfg.HomePath, relPath))
+}
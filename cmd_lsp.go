@@ -0,0 +1,139 @@
+package notes
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+
+	"github.com/naterator/notes/links"
+	"github.com/naterator/notes/lsp"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// LspCmd represents `notes lsp` command. It runs a Language Server Protocol server over
+// stdio so editors (Neovim, VS Code, Emacs, ...) can integrate with the notes tree without
+// shelling out to this binary per keystroke.
+type LspCmd struct {
+	cli    *kingpin.CmdClause
+	Config *Config
+}
+
+func (cmd *LspCmd) defineCLI(app *kingpin.Application) {
+	cmd.cli = app.Command("lsp", "Run a Language Server Protocol server over stdio for editor integration")
+}
+
+func (cmd *LspCmd) matchesCmdline(cmdline string) bool {
+	return cmd.cli.FullCommand() == cmdline
+}
+
+// Do runs `notes lsp` command and returns an error if occurs
+func (cmd *LspCmd) Do() error {
+	server := lsp.NewServer(&lspBackend{cfg: cmd.Config})
+	return server.Run(context.Background(), os.Stdin, os.Stdout)
+}
+
+// lspBackend adapts *Config/FindCmd to lsp.Backend, keeping the CLI and the editor server
+// on the same query/index code path so results stay consistent between the two.
+type lspBackend struct {
+	cfg *Config
+}
+
+func (b *lspBackend) Root() string {
+	return b.cfg.HomePath
+}
+
+func (b *lspBackend) Search(query string, limit int) ([]lsp.Note, error) {
+	find := &FindCmd{Config: b.cfg, Query: query}
+	matched, _, err := find.searchViaIndex(strings.ToLower(strings.TrimSpace(query)))
+	if err != nil {
+		matched, err = find.scanForQuery(strings.ToLower(strings.TrimSpace(query)))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+	}
+
+	notes := make([]lsp.Note, 0, len(matched))
+	for _, n := range matched {
+		notes = append(notes, noteToLsp(n))
+	}
+	return notes, nil
+}
+
+// Resolve looks target up via the same links.Resolve/scanCandidates path the CLI's
+// `notes links`/`notes backlinks` use, so a `[[path/to/note]]` resolves to the same note
+// in the editor as it does on the command line.
+func (b *lspBackend) Resolve(target string) (lsp.Note, bool, error) {
+	candidates, err := scanCandidates(b.cfg)
+	if err != nil {
+		return lsp.Note{}, false, err
+	}
+
+	p, ok := links.Resolve(target, candidates)
+	if !ok {
+		return lsp.Note{}, false, nil
+	}
+
+	note, err := LoadNote(p, b.cfg)
+	if err != nil {
+		return lsp.Note{}, false, err
+	}
+	return noteToLsp(note), true, nil
+}
+
+func (b *lspBackend) ListTags() ([]string, error) {
+	cats, err := CollectCategories(b.cfg, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var tags []string
+	for _, cat := range cats {
+		for _, p := range cat.NotePaths {
+			note, err := LoadNote(p, b.cfg)
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range note.Tags {
+				if !seen[t] {
+					seen[t] = true
+					tags = append(tags, t)
+				}
+			}
+		}
+	}
+	return tags, nil
+}
+
+func (b *lspBackend) NewNote(category, title string) (string, error) {
+	var buf bytes.Buffer
+	cmd := &NewCmd{Config: b.cfg, Category: category, Title: title, Out: &buf}
+	if err := cmd.Do(); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+func (b *lspBackend) Refresh() error {
+	idx, err := openIndexIfAvailable(b.cfg)
+	if err != nil || idx == nil {
+		return err
+	}
+	defer idx.Close()
+	return syncIndex(idx, b.cfg)
+}
+
+func noteToLsp(n *Note) lsp.Note {
+	return lsp.Note{
+		Path:     n.FilePath(),
+		Title:    n.Title,
+		Tags:     n.Tags,
+		Created:  n.Created,
+		Modified: n.Modified,
+	}
+}
@@ -0,0 +1,26 @@
+package notes
+
+import (
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// ReindexCmd represents `notes reindex` command. It drops and rebuilds the SQLite search
+// index for the whole notes tree, which is useful after bulk edits made outside of `notes
+// edit` or whenever the index is suspected to be stale or corrupted.
+type ReindexCmd struct {
+	cli    *kingpin.CmdClause
+	Config *Config
+}
+
+func (cmd *ReindexCmd) defineCLI(app *kingpin.Application) {
+	cmd.cli = app.Command("reindex", "Rebuild the search index used by 'find' and 'list' from scratch")
+}
+
+func (cmd *ReindexCmd) matchesCmdline(cmdline string) bool {
+	return cmd.cli.FullCommand() == cmdline
+}
+
+// Do runs `notes reindex` command and returns an error if occurs
+func (cmd *ReindexCmd) Do() error {
+	return rebuildIndex(cmd.Config)
+}
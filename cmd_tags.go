@@ -0,0 +1,98 @@
+package notes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// TagListCmd represents `notes tags` command. Each public field represents options of the
+// command. Out field represents where this command should output.
+type TagListCmd struct {
+	cli    *kingpin.CmdClause
+	Config *Config
+	// SortBy is a string indicating how to sort the list. This value is equivalent to
+	// --sort option. Valid values are 'count' and 'name'. Default is 'count'
+	SortBy string
+	// JSON is a flag equivalent to --json
+	JSON bool
+	// CoOccurring is equivalent to --co-occurring. When set, the listed tags are the ones
+	// appearing alongside this tag rather than every tag in the tree
+	CoOccurring string
+	// Out is a writer to write output of this command. Kind of stdout is expected
+	Out io.Writer
+}
+
+func (cmd *TagListCmd) defineCLI(app *kingpin.Application) {
+	cmd.cli = app.Command("tags", "List tags used across notes with their counts")
+	cmd.cli.Flag("sort", "Sort list by 'count' or 'name'. Default is 'count'").Short('s').EnumVar(&cmd.SortBy, "count", "name")
+	cmd.cli.Flag("json", "Output as JSON for editor/tool consumption").BoolVar(&cmd.JSON)
+	cmd.cli.Flag("co-occurring", "Show tags that appear alongside the given tag, with counts").StringVar(&cmd.CoOccurring)
+}
+
+func (cmd *TagListCmd) matchesCmdline(cmdline string) bool {
+	return cmd.cli.FullCommand() == cmdline
+}
+
+// Do runs `notes tags` command and returns an error if occurs
+func (cmd *TagListCmd) Do() error {
+	var tags []TagCount
+	var err error
+	if cmd.CoOccurring != "" {
+		tags, err = CoOccurringTags(cmd.Config, cmd.CoOccurring)
+	} else {
+		tags, err = CollectTags(cmd.Config)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(cmd.SortBy) {
+	case "name":
+		sortTagCountsByName(tags)
+	default:
+		sortTagCountsByCount(tags)
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	if cmd.Config.PagerCmd == "" {
+		return cmd.print(cmd.Out, tags)
+	}
+
+	pager, err := StartPagerWriter(cmd.Config.PagerCmd, cmd.Out)
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.print(pager, tags); err != nil {
+		if pager.Err != nil {
+			err = errors.Wrap(err, "Pager command did not run successfully")
+		}
+		return err
+	}
+
+	pager.Wait()
+	return errors.Wrap(pager.Err, "Pager command did not run successfully")
+}
+
+func (cmd *TagListCmd) print(w io.Writer, tags []TagCount) error {
+	if cmd.JSON {
+		return json.NewEncoder(w).Encode(tags)
+	}
+
+	var b bytes.Buffer
+	for _, t := range tags {
+		fmt.Fprintf(&b, "%s %s\n", color.YellowString("%4d", t.Count), t.Name)
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
@@ -0,0 +1,55 @@
+package notes
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestTagListCmdJSON(t *testing.T) {
+	old := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = old }()
+
+	cfg := testNewConfigForListCmd("normal")
+	var buf bytes.Buffer
+	cmd := &TagListCmd{Config: cfg, Out: &buf, JSON: true}
+
+	if err := cmd.Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	var tags []TagCount
+	if err := json.Unmarshal(buf.Bytes(), &tags); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if len(tags) == 0 {
+		t.Fatal("Expected at least one tag")
+	}
+}
+
+func TestTagListCmdSortByName(t *testing.T) {
+	old := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = old }()
+
+	cfg := testNewConfigForListCmd("normal")
+	var buf bytes.Buffer
+	cmd := &TagListCmd{Config: cfg, Out: &buf, JSON: true, SortBy: "name"}
+
+	if err := cmd.Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	var tags []TagCount
+	if err := json.Unmarshal(buf.Bytes(), &tags); err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < len(tags); i++ {
+		if tags[i-1].Name > tags[i].Name {
+			t.Fatalf("Tags are not sorted by name: %v", tags)
+		}
+	}
+}
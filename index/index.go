@@ -0,0 +1,341 @@
+//go:build !nosqlite
+// +build !nosqlite
+
+// Package index maintains a persistent SQLite FTS5 search index for a notes tree.
+//
+// The index lives as a single database file, typically "$NOTES_HOME/.notes/index.db".
+// Callers are expected to keep it in sync themselves: stat each note, compare it against
+// Stat, and Upsert only the ones that changed. This package intentionally knows nothing
+// about the notes package's Config/Note types to avoid an import cycle; callers translate
+// their own types into Record before calling Upsert.
+package index
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS notes (
+	id INTEGER PRIMARY KEY,
+	path TEXT UNIQUE NOT NULL,
+	category TEXT NOT NULL,
+	tags TEXT NOT NULL,
+	title TEXT NOT NULL,
+	body TEXT NOT NULL,
+	created DATETIME NOT NULL,
+	modified DATETIME NOT NULL,
+	size INTEGER NOT NULL,
+	checksum TEXT NOT NULL
+);
+CREATE VIRTUAL TABLE IF NOT EXISTS notes_fts USING fts5(
+	title, tags, body,
+	content='notes',
+	content_rowid='id',
+	tokenize='porter unicode61'
+);
+CREATE TRIGGER IF NOT EXISTS notes_ai AFTER INSERT ON notes BEGIN
+	INSERT INTO notes_fts(rowid, title, tags, body) VALUES (new.id, new.title, new.tags, new.body);
+END;
+CREATE TRIGGER IF NOT EXISTS notes_ad AFTER DELETE ON notes BEGIN
+	INSERT INTO notes_fts(notes_fts, rowid, title, tags, body) VALUES ('delete', old.id, old.title, old.tags, old.body);
+END;
+CREATE TRIGGER IF NOT EXISTS notes_au AFTER UPDATE ON notes BEGIN
+	INSERT INTO notes_fts(notes_fts, rowid, title, tags, body) VALUES ('delete', old.id, old.title, old.tags, old.body);
+	INSERT INTO notes_fts(rowid, title, tags, body) VALUES (new.id, new.title, new.tags, new.body);
+END;
+CREATE TABLE IF NOT EXISTS links (
+	from_path TEXT NOT NULL,
+	to_path TEXT NOT NULL,
+	PRIMARY KEY (from_path, to_path)
+);
+CREATE INDEX IF NOT EXISTS links_to_idx ON links(to_path);
+`
+
+// Record is the subset of a note's metadata and content that gets persisted in the index.
+type Record struct {
+	Path     string
+	Category string
+	Tags     []string
+	Title    string
+	Body     string
+	Created  time.Time
+	Modified time.Time
+	Size     int64
+	Checksum string
+}
+
+// Result is a single search hit returned from Search.
+type Result struct {
+	Path    string
+	Rank    float64
+	Snippet string
+}
+
+// Index wraps a SQLite database holding the FTS5-backed notes index.
+type Index struct {
+	db *sql.DB
+}
+
+// Open opens (creating and migrating if necessary) the index database at dbPath.
+func Open(dbPath string) (*Index, error) {
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
+		return nil, errors.Wrapf(err, "Cannot create directory for index at %q", dbPath)
+	}
+
+	db, err := sql.Open("sqlite3", dbPath+"?_journal_mode=WAL&_foreign_keys=on")
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot open index database %q", dbPath)
+	}
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, errors.Wrap(err, "Cannot create index schema")
+	}
+
+	return &Index{db: db}, nil
+}
+
+// Close closes the underlying database connection.
+func (idx *Index) Close() error {
+	return idx.db.Close()
+}
+
+// Stat reports the size and checksum currently stored for path, so the caller can decide
+// whether the file needs to be re-indexed. ok is false when path is not indexed yet.
+func (idx *Index) Stat(path string) (modified time.Time, size int64, checksum string, ok bool, err error) {
+	row := idx.db.QueryRow(`SELECT modified, size, checksum FROM notes WHERE path = ?`, path)
+	err = row.Scan(&modified, &size, &checksum)
+	if err == sql.ErrNoRows {
+		return time.Time{}, 0, "", false, nil
+	}
+	if err != nil {
+		return time.Time{}, 0, "", false, errors.Wrapf(err, "Cannot stat indexed note %q", path)
+	}
+	return modified, size, checksum, true, nil
+}
+
+// Upsert inserts or replaces the indexed record for r.Path.
+func (idx *Index) Upsert(r Record) error {
+	_, err := idx.db.Exec(
+		`INSERT INTO notes (path, category, tags, title, body, created, modified, size, checksum)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(path) DO UPDATE SET
+			category = excluded.category,
+			tags = excluded.tags,
+			title = excluded.title,
+			body = excluded.body,
+			created = excluded.created,
+			modified = excluded.modified,
+			size = excluded.size,
+			checksum = excluded.checksum`,
+		r.Path, r.Category, strings.Join(r.Tags, ","), r.Title, r.Body, r.Created, r.Modified, r.Size, r.Checksum,
+	)
+	return errors.Wrapf(err, "Cannot index note %q", r.Path)
+}
+
+// Remove deletes the indexed record for path, e.g. when the underlying file was deleted
+// since the last index run.
+func (idx *Index) Remove(path string) error {
+	_, err := idx.db.Exec(`DELETE FROM notes WHERE path = ?`, path)
+	return errors.Wrapf(err, "Cannot remove %q from index", path)
+}
+
+// Paths returns every path currently stored in the index, used to reconcile the index
+// against the notes actually on disk (e.g. pruning rows for deleted files).
+func (idx *Index) Paths() ([]string, error) {
+	rows, err := idx.db.Query(`SELECT path FROM notes`)
+	if err != nil {
+		return nil, errors.Wrap(err, "Cannot list indexed paths")
+	}
+	defer rows.Close()
+
+	var paths []string
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return nil, errors.Wrap(err, "Cannot scan indexed path")
+		}
+		paths = append(paths, p)
+	}
+	return paths, errors.Wrap(rows.Err(), "Error while reading indexed paths")
+}
+
+// Reset drops every indexed record so a full rebuild can start from scratch.
+func (idx *Index) Reset() error {
+	_, err := idx.db.Exec(`DELETE FROM notes; DELETE FROM links`)
+	return errors.Wrap(err, "Cannot reset index")
+}
+
+// SetLinks replaces the stored outbound edges for path with targets, resolved paths of
+// the notes it links to. Persisting resolved edges (rather than raw link text) keeps
+// Outbound/Inbound lookups O(1).
+func (idx *Index) SetLinks(path string, targets []string) error {
+	tx, err := idx.db.Begin()
+	if err != nil {
+		return errors.Wrapf(err, "Cannot begin transaction to set links for %q", path)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM links WHERE from_path = ?`, path); err != nil {
+		tx.Rollback()
+		return errors.Wrapf(err, "Cannot clear previous links for %q", path)
+	}
+
+	for _, to := range targets {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO links (from_path, to_path) VALUES (?, ?)`, path, to); err != nil {
+			tx.Rollback()
+			return errors.Wrapf(err, "Cannot link %q -> %q", path, to)
+		}
+	}
+
+	return errors.Wrapf(tx.Commit(), "Cannot commit links for %q", path)
+}
+
+// Outbound returns every resolved link target path stored for path.
+func (idx *Index) Outbound(path string) ([]string, error) {
+	return idx.queryLinks(`SELECT to_path FROM links WHERE from_path = ? ORDER BY to_path`, path)
+}
+
+// Inbound returns the path of every note with an outbound edge to path, i.e. its backlinks.
+func (idx *Index) Inbound(path string) ([]string, error) {
+	return idx.queryLinks(`SELECT from_path FROM links WHERE to_path = ? ORDER BY from_path`, path)
+}
+
+func (idx *Index) queryLinks(query, path string) ([]string, error) {
+	rows, err := idx.db.Query(query, path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot query links for %q", path)
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return nil, errors.Wrap(err, "Cannot scan link row")
+		}
+		out = append(out, p)
+	}
+	return out, errors.Wrap(rows.Err(), "Error while reading links")
+}
+
+// Search runs query (free text, or field-scoped syntax like `title:foo tags:golang
+// body:"exact phrase"`) against the index and returns at most limit results ordered by
+// BM25 relevance, weighted title > tags > body. An empty query returns every indexed note.
+func (idx *Index) Search(query string, limit int) ([]Result, error) {
+	if strings.TrimSpace(query) == "" {
+		return idx.listAll(limit)
+	}
+
+	fts := toFTS5Query(query)
+
+	rows, err := idx.db.Query(
+		`SELECT notes.path,
+			bm25(notes_fts, 1000.0, 500.0, 1.0) AS rank,
+			snippet(notes_fts, 2, '`+"\x1b[1m"+`', '`+"\x1b[0m"+`', '…', 20)
+		 FROM notes_fts
+		 JOIN notes ON notes.id = notes_fts.rowid
+		 WHERE notes_fts MATCH ?
+		 ORDER BY rank
+		 LIMIT ?`,
+		fts, limit,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Cannot run search query %q", query)
+	}
+	defer rows.Close()
+
+	return scanResults(rows)
+}
+
+func (idx *Index) listAll(limit int) ([]Result, error) {
+	rows, err := idx.db.Query(`SELECT path, 0, '' FROM notes ORDER BY created DESC LIMIT ?`, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "Cannot list indexed notes")
+	}
+	defer rows.Close()
+
+	return scanResults(rows)
+}
+
+func scanResults(rows *sql.Rows) ([]Result, error) {
+	var results []Result
+	for rows.Next() {
+		var r Result
+		if err := rows.Scan(&r.Path, &r.Rank, &r.Snippet); err != nil {
+			return nil, errors.Wrap(err, "Cannot scan search result")
+		}
+		results = append(results, r)
+	}
+	return results, errors.Wrap(rows.Err(), "Error while reading search results")
+}
+
+// toFTS5Query translates a user-facing query into FTS5 MATCH syntax: field-scoped terms
+// (title:foo), quoted phrases (body:"exact phrase") and bare terms are all quoted to avoid
+// FTS5 syntax errors on punctuation, then joined with AND.
+func toFTS5Query(query string) string {
+	fields := splitQueryFields(query)
+	terms := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if col, rest, ok := strings.Cut(f, ":"); ok && isSearchColumn(col) {
+			terms = append(terms, fmt.Sprintf("%s:%s", col, quoteFTS5Term(rest)))
+			continue
+		}
+		terms = append(terms, quoteFTS5Term(f))
+	}
+	return strings.Join(terms, " AND ")
+}
+
+// splitQueryFields splits query on whitespace like strings.Fields, except it keeps a
+// double-quoted span together as one field even when it contains spaces, so a field-
+// scoped phrase like body:"exact phrase" survives as a single token.
+func splitQueryFields(query string) []string {
+	var fields []string
+	var cur strings.Builder
+	inQuotes := false
+
+	flush := func() {
+		if cur.Len() > 0 {
+			fields = append(fields, cur.String())
+			cur.Reset()
+		}
+	}
+
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			cur.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuotes:
+			flush()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+	return fields
+}
+
+func isSearchColumn(col string) bool {
+	switch col {
+	case "title", "tags", "body":
+		return true
+	default:
+		return false
+	}
+}
+
+func quoteFTS5Term(term string) string {
+	if len(term) >= 2 && strings.HasPrefix(term, `"`) && strings.HasSuffix(term, `"`) {
+		term = term[1 : len(term)-1]
+	}
+	return `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+}
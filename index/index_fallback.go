@@ -0,0 +1,41 @@
+//go:build nosqlite
+// +build nosqlite
+
+// This file provides a stub implementation of the index package for binaries built with
+// the `nosqlite` tag (no cgo, no SQLite driver available). Callers detect ErrUnavailable
+// and fall back to scanning the notes tree directly.
+package index
+
+import "github.com/pkg/errors"
+
+// ErrUnavailable is returned by every operation in this build of the package except Close,
+// which returns nil.
+var ErrUnavailable = errors.New("SQLite index is unavailable in this build (built with 'nosqlite' tag)")
+
+// Record is an empty placeholder in this build; only the non-stub build gives it fields.
+type Record struct{}
+
+// Result is kept in sync with the non-stub build so callers can share the same call sites.
+type Result struct {
+	Path    string
+	Rank    float64
+	Snippet string
+}
+
+// Index is an unusable placeholder in this build.
+type Index struct{}
+
+// Open always fails with ErrUnavailable in this build.
+func Open(dbPath string) (*Index, error) {
+	return nil, ErrUnavailable
+}
+
+func (idx *Index) Close() error                                 { return nil }
+func (idx *Index) Upsert(r Record) error                        { return ErrUnavailable }
+func (idx *Index) Remove(path string) error                     { return ErrUnavailable }
+func (idx *Index) Paths() ([]string, error)                     { return nil, ErrUnavailable }
+func (idx *Index) Reset() error                                 { return ErrUnavailable }
+func (idx *Index) Search(q string, limit int) ([]Result, error) { return nil, ErrUnavailable }
+func (idx *Index) SetLinks(path string, targets []string) error { return ErrUnavailable }
+func (idx *Index) Outbound(path string) ([]string, error)       { return nil, ErrUnavailable }
+func (idx *Index) Inbound(path string) ([]string, error)        { return nil, ErrUnavailable }
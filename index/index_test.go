@@ -0,0 +1,38 @@
+//go:build !nosqlite
+// +build !nosqlite
+
+package index
+
+import "testing"
+
+func TestToFTS5QueryBareTerms(t *testing.T) {
+	have := toFTS5Query("golang notes")
+	want := `"golang" AND "notes"`
+	if have != want {
+		t.Fatalf("want %q but have %q", want, have)
+	}
+}
+
+func TestToFTS5QueryFieldScoped(t *testing.T) {
+	have := toFTS5Query(`title:foo tags:golang body:"exact phrase"`)
+	want := `title:"foo" AND tags:"golang" AND body:"exact phrase"`
+	if have != want {
+		t.Fatalf("want %q but have %q", want, have)
+	}
+}
+
+func TestToFTS5QueryUnknownFieldTreatedAsBareTerm(t *testing.T) {
+	have := toFTS5Query("unknown:foo")
+	want := `"unknown:foo"`
+	if have != want {
+		t.Fatalf("want %q but have %q", want, have)
+	}
+}
+
+func TestQuoteFTS5TermEscapesQuotes(t *testing.T) {
+	have := quoteFTS5Term(`say "hi"`)
+	want := `"say ""hi"""`
+	if have != want {
+		t.Fatalf("want %q but have %q", want, have)
+	}
+}
@@ -0,0 +1,116 @@
+// Package links parses wiki-style and markdown links out of a note's body and resolves
+// them to concrete notes. It is self-contained (no dependency on the notes package's
+// Config/Note types) so it can be shared by the root notes package and the lsp package
+// without an import cycle.
+package links
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	wikiLinkPattern     = regexp.MustCompile(`\[\[([^\]]+)\]\]`)
+	markdownLinkPattern = regexp.MustCompile(`\[[^\]]*\]\(([^)]+)\)`)
+)
+
+// Candidate is a note that a link target can resolve to.
+type Candidate struct {
+	Path  string
+	Title string
+}
+
+// ExtractTargets returns every wiki-style (`[[Title]]`, `[[path/to/note]]`) and markdown
+// (`[text](path.md)`) link target found in body, skipping external (http/https) markdown
+// links. Wiki-style targets come first, then markdown targets, each group in the order its
+// targets first appear.
+func ExtractTargets(body string) []string {
+	var targets []string
+	seen := map[string]bool{}
+
+	add := func(t string) {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[t] {
+			return
+		}
+		seen[t] = true
+		targets = append(targets, t)
+	}
+
+	for _, m := range wikiLinkPattern.FindAllStringSubmatch(body, -1) {
+		add(m[1])
+	}
+	for _, m := range markdownLinkPattern.FindAllStringSubmatch(body, -1) {
+		href := m[1]
+		if strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://") {
+			continue
+		}
+		add(href)
+	}
+
+	return targets
+}
+
+// Occurrence is a single wiki-style or markdown link found in a note's body, together with
+// the byte range it spans in the source text. Unlike ExtractTargets, it keeps every
+// occurrence (no deduplication) and its position, so callers like the LSP server's
+// documentLink/definition/hover handlers can map a cursor offset back to the link under it.
+type Occurrence struct {
+	Target     string
+	Start, End int
+}
+
+// Occurrences returns every wiki-style and markdown link found in body, in the order they
+// appear, skipping external (http/https) markdown links just like ExtractTargets does.
+func Occurrences(body string) []Occurrence {
+	var occs []Occurrence
+
+	for _, m := range wikiLinkPattern.FindAllStringSubmatchIndex(body, -1) {
+		occs = append(occs, Occurrence{Target: strings.TrimSpace(body[m[2]:m[3]]), Start: m[0], End: m[1]})
+	}
+	for _, m := range markdownLinkPattern.FindAllStringSubmatchIndex(body, -1) {
+		href := body[m[2]:m[3]]
+		if strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://") {
+			continue
+		}
+		occs = append(occs, Occurrence{Target: strings.TrimSpace(href), Start: m[0], End: m[1]})
+	}
+
+	sort.Slice(occs, func(i, j int) bool { return occs[i].Start < occs[j].Start })
+	return occs
+}
+
+// Resolve finds which candidate target refers to. It tries, in order: an exact filename
+// match (e.g. "note" or "note.md" against ".../note.md"), then a note with that exact
+// title, then a path whose suffix matches target. The filename step compares the whole
+// target with each file's base name, so a sub-path like "notes/meeting.md" only resolves
+// in the path-suffix step, to a note whose path ends in "notes/meeting.md". A folder name
+// never matches: "meeting" resolves to ".../meeting.md", not to a note under a folder named
+// "meeting". ok is false when nothing matches.
+func Resolve(target string, candidates []Candidate) (path string, ok bool) {
+	target = strings.TrimSuffix(target, filepath.Ext(target))
+
+	for _, c := range candidates {
+		name := strings.TrimSuffix(filepath.Base(c.Path), filepath.Ext(c.Path))
+		if name == target {
+			return c.Path, true
+		}
+	}
+
+	for _, c := range candidates {
+		if c.Title == target {
+			return c.Path, true
+		}
+	}
+
+	for _, c := range candidates {
+		p := strings.TrimSuffix(c.Path, filepath.Ext(c.Path))
+		if p == target || strings.HasSuffix(p, "/"+target) {
+			return c.Path, true
+		}
+	}
+
+	return "", false
+}
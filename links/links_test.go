@@ -0,0 +1,68 @@
+package links
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractTargetsWikiAndMarkdown(t *testing.T) {
+	body := `See [[Meeting Notes]] and [[archive/2024.md]].
+
+Also check [the plan](plans/q3.md) and [external](https://example.com/page).`
+
+	have := ExtractTargets(body)
+	want := []string{"Meeting Notes", "archive/2024.md", "plans/q3.md"}
+	if !reflect.DeepEqual(have, want) {
+		t.Fatalf("want %v but have %v", want, have)
+	}
+}
+
+func TestExtractTargetsDeduplicates(t *testing.T) {
+	body := "[[Same]] and [[Same]] again"
+	have := ExtractTargets(body)
+	want := []string{"Same"}
+	if !reflect.DeepEqual(have, want) {
+		t.Fatalf("want %v but have %v", want, have)
+	}
+}
+
+func TestResolveFilenameBeatsFolderName(t *testing.T) {
+	candidates := []Candidate{
+		{Path: "a/meeting/notes.md", Title: "Folder notes"},
+		{Path: "b/meeting.md", Title: "Meeting"},
+	}
+
+	path, ok := Resolve("meeting", candidates)
+	if !ok || path != "b/meeting.md" {
+		t.Fatalf("want b/meeting.md but have %q (ok=%v)", path, ok)
+	}
+}
+
+func TestResolvePathSuffixRespectsComponentBoundary(t *testing.T) {
+	candidates := []Candidate{
+		{Path: "blog/fintech/note.md", Title: "Fintech note"},
+	}
+
+	_, ok := Resolve("tech/note", candidates)
+	if ok {
+		t.Fatal("expected no match: \"tech/note\" is not a path-component suffix of \"blog/fintech/note.md\"")
+	}
+}
+
+func TestResolveByTitle(t *testing.T) {
+	candidates := []Candidate{
+		{Path: "a/1.md", Title: "Meeting Notes"},
+	}
+
+	path, ok := Resolve("Meeting Notes", candidates)
+	if !ok || path != "a/1.md" {
+		t.Fatalf("want a/1.md but have %q (ok=%v)", path, ok)
+	}
+}
+
+func TestResolveNotFound(t *testing.T) {
+	_, ok := Resolve("nope", []Candidate{{Path: "a/1.md", Title: "Something"}})
+	if ok {
+		t.Fatal("expected no match")
+	}
+}
@@ -0,0 +1,96 @@
+package notes
+
+import (
+	"path/filepath"
+
+	"github.com/naterator/notes/links"
+	"github.com/pkg/errors"
+)
+
+// BrokenLink is a link found by `notes links --broken`: a note (From) containing a link
+// whose target text (Target) did not resolve to any note in the tree.
+type BrokenLink struct {
+	From   string
+	Target string
+}
+
+// relNotePath normalizes a user-supplied note path to the path relative to $NOTES_HOME that
+// note paths are stored as internally. An absolute path is made relative to $NOTES_HOME; any
+// other path is taken to be relative to $NOTES_HOME already, not to the current directory.
+func relNotePath(cfg *Config, path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		return filepath.ToSlash(path), nil
+	}
+	rel, err := filepath.Rel(cfg.HomePath, path)
+	if err != nil {
+		return "", errors.Wrapf(err, "Cannot resolve %q relative to $NOTES_HOME", path)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// loadNotesByPath loads each of paths (relative to $NOTES_HOME) as a Note, in order.
+func loadNotesByPath(cfg *Config, paths []string) ([]*Note, error) {
+	notes := make([]*Note, 0, len(paths))
+	for _, p := range paths {
+		note, err := LoadNote(p, cfg)
+		if err != nil {
+			return nil, err
+		}
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
+
+// outboundLinks returns the resolved targets relPath links to, preferring the SQLite index
+// and falling back to a direct scan when the index is unavailable.
+func outboundLinks(cfg *Config, relPath string) ([]string, error) {
+	if idx, err := openIndexIfAvailable(cfg); err == nil && idx != nil {
+		defer idx.Close()
+		if err := syncIndex(idx, cfg); err == nil {
+			return idx.Outbound(relPath)
+		}
+	}
+	return scanOutbound(cfg, relPath)
+}
+
+// inboundLinks returns every note with a resolved link to relPath (its backlinks),
+// preferring the SQLite index and falling back to a direct scan when unavailable.
+func inboundLinks(cfg *Config, relPath string) ([]string, error) {
+	if idx, err := openIndexIfAvailable(cfg); err == nil && idx != nil {
+		defer idx.Close()
+		if err := syncIndex(idx, cfg); err == nil {
+			return idx.Inbound(relPath)
+		}
+	}
+	return scanInbound(cfg, relPath)
+}
+
+// brokenLinks scans the whole tree for links whose target text does not resolve to any
+// note, useful for periodic cleanup.
+func brokenLinks(cfg *Config) ([]BrokenLink, error) {
+	cats, err := CollectCategories(cfg, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := scanCandidates(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var broken []BrokenLink
+	for _, cat := range cats {
+		for _, p := range cat.NotePaths {
+			body, err := readNoteBody(cfg, p)
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range links.ExtractTargets(body) {
+				if _, ok := links.Resolve(t, candidates); !ok {
+					broken = append(broken, BrokenLink{From: p, Target: t})
+				}
+			}
+		}
+	}
+	return broken, nil
+}
@@ -0,0 +1,82 @@
+package notes
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// defineListOutputFlags registers the --relative, --sort and --edit flags shared by
+// FindCmd, LinksCmd and BacklinksCmd.
+func defineListOutputFlags(cli *kingpin.CmdClause, relative *bool, sortBy *string, edit *bool) {
+	cli.Flag("relative", "Show relative paths from $NOTES_HOME directory").Short('r').BoolVar(relative)
+	cli.Flag("sort", "Sort list by 'modified', 'created', 'filename' or 'category'. Default is 'created'").Short('s').EnumVar(sortBy, "modified", "created", "filename", "category")
+	cli.Flag("edit", "Open listed notes with your favorite editor. $NOTES_EDITOR must be set. Paths of listed notes are passed to the editor command's arguments").Short('e').BoolVar(edit)
+}
+
+// printNoteList sorts notes per sortBy and writes them to out: as editor args when edit is
+// set, as relative paths when relative is set, or as the usual oneline listing otherwise.
+// It is the output half of FindCmd.printNotes, shared with LinksCmd and BacklinksCmd.
+func printNoteList(cfg *Config, out io.Writer, notes []*Note, sortBy string, edit, relative bool) error {
+	switch strings.ToLower(sortBy) {
+	case "filename":
+		sortByFilename(notes)
+	case "category":
+		sortByCategory(notes)
+	case "modified":
+		if err := sortByModified(notes); err != nil {
+			return err
+		}
+	default:
+		sortByCreated(notes)
+	}
+
+	if edit {
+		args := make([]string, 0, len(notes))
+		for _, n := range notes {
+			args = append(args, n.FilePath())
+		}
+		return openEditor(cfg, args...)
+	}
+
+	if relative {
+		var b bytes.Buffer
+		for _, note := range notes {
+			b.WriteString(note.RelFilePath())
+			b.WriteRune('\n')
+		}
+		_, err := out.Write(b.Bytes())
+		return err
+	}
+
+	return printOnelineNotesTo(out, notes)
+}
+
+// runWithPager prints notes via print, routed through cfg.PagerCmd when one is set.
+func runWithPager(cfg *Config, w io.Writer, notes []*Note, print func(io.Writer, []*Note) error) error {
+	if len(notes) == 0 {
+		return nil
+	}
+
+	if cfg.PagerCmd == "" {
+		return print(w, notes)
+	}
+
+	pager, err := StartPagerWriter(cfg.PagerCmd, w)
+	if err != nil {
+		return err
+	}
+
+	if err := print(pager, notes); err != nil {
+		if pager.Err != nil {
+			err = errors.Wrap(err, "Pager command did not run successfully")
+		}
+		return err
+	}
+
+	pager.Wait()
+	return errors.Wrap(pager.Err, "Pager command did not run successfully")
+}
@@ -0,0 +1,421 @@
+// Package lsp implements a Language Server Protocol server (over stdio) for a notes tree,
+// so editors can get completion, navigation and search without shelling out to the `notes`
+// binary on every keystroke.
+//
+// This package intentionally knows nothing about the notes package's Config/Note types to
+// avoid an import cycle (the root notes package is the one that registers `notes lsp` as a
+// subcommand). Callers implement Backend to adapt their own note model.
+package lsp
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/naterator/notes/links"
+	"github.com/pkg/errors"
+	"github.com/tliron/glsp"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+	glspserv "github.com/tliron/glsp/server"
+)
+
+// Note is the subset of note metadata the LSP layer needs to render completions, hovers,
+// links and symbols. Callers translate their own note type into this before returning it
+// from Backend.
+type Note struct {
+	Path     string
+	Title    string
+	Tags     []string
+	Created  time.Time
+	Modified time.Time
+}
+
+// Backend adapts a notes tree to the LSP server. Implementations live in the caller
+// (typically the root notes package, wrapping *notes.Config).
+type Backend interface {
+	// Root returns the directory the server should watch for changes, e.g. $NOTES_HOME.
+	Root() string
+	// Search runs the same query/ranking FindCmd uses and returns at most limit notes.
+	Search(query string, limit int) ([]Note, error)
+	// Resolve looks up a single note by wiki-link target or path suffix, as used to resolve
+	// `[[Title]]` links and `textDocument/definition` requests.
+	Resolve(target string) (Note, bool, error)
+	// ListTags returns every known tag, used to serve `notes.tag.list`.
+	ListTags() ([]string, error)
+	// NewNote creates a note in category with title and returns its path, used to serve
+	// `notes.new`.
+	NewNote(category, title string) (string, error)
+	// Refresh re-syncs whatever backs Search/Resolve/ListTags with the notes tree on disk,
+	// called by Run's file watcher whenever it sees a change.
+	Refresh() error
+}
+
+// Server is a Language Server Protocol server for a notes tree.
+type Server struct {
+	backend Backend
+	glsp    *glspserv.Server
+	docs    *documentStore
+}
+
+// NewServer builds a Server backed by backend. Call Run to start serving over stdio.
+func NewServer(backend Backend) *Server {
+	s := &Server{backend: backend, docs: newDocumentStore()}
+
+	handler := protocol.Handler{
+		Initialize:               s.initialize,
+		TextDocumentDidOpen:      s.didOpen,
+		TextDocumentDidChange:    s.didChange,
+		TextDocumentDidClose:     s.didClose,
+		TextDocumentCompletion:   s.completion,
+		TextDocumentDefinition:   s.definition,
+		TextDocumentDocumentLink: s.documentLink,
+		TextDocumentHover:        s.hover,
+		WorkspaceSymbol:          s.workspaceSymbol,
+		WorkspaceExecuteCommand:  s.executeCommand,
+	}
+
+	s.glsp = glspserv.NewServer(&handler, "notes-lsp", false)
+	return s
+}
+
+// documentStore tracks the text of currently open documents, keyed by URI, so handlers
+// that need to look at what's under the cursor (completion, definition, hover,
+// documentLink) see the editor's in-memory buffer rather than re-reading the file from
+// disk, which may be behind unsaved edits.
+type documentStore struct {
+	mu   sync.Mutex
+	text map[string]string
+}
+
+func newDocumentStore() *documentStore {
+	return &documentStore{text: map[string]string{}}
+}
+
+func (d *documentStore) set(uri, text string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.text[uri] = text
+}
+
+func (d *documentStore) get(uri string) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.text[uri]
+}
+
+func (d *documentStore) delete(uri string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.text, uri)
+}
+
+func (s *Server) didOpen(ctx *glsp.Context, params *protocol.DidOpenTextDocumentParams) error {
+	s.docs.set(params.TextDocument.URI, params.TextDocument.Text)
+	return nil
+}
+
+func (s *Server) didChange(ctx *glsp.Context, params *protocol.DidChangeTextDocumentParams) error {
+	for _, change := range params.ContentChanges {
+		if whole, ok := change.(protocol.TextDocumentContentChangeEventWhole); ok {
+			s.docs.set(params.TextDocument.URI, whole.Text)
+		}
+	}
+	return nil
+}
+
+func (s *Server) didClose(ctx *glsp.Context, params *protocol.DidCloseTextDocumentParams) error {
+	s.docs.delete(params.TextDocument.URI)
+	return nil
+}
+
+// Run starts serving LSP requests over in/out (typically os.Stdin/os.Stdout) and watches
+// the backend's root directory, blocking until the client disconnects or ctx is canceled.
+func (s *Server) Run(ctx context.Context, in io.Reader, out io.Writer) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "Cannot start filesystem watcher for notes tree")
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(s.backend.Root()); err != nil {
+		return errors.Wrapf(err, "Cannot watch notes directory %q", s.backend.Root())
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				// Incremental refresh: the backend decides what "changed" means (e.g. the
+				// same per-path size and checksum comparison the SQLite index uses).
+				_ = s.backend.Refresh()
+			case <-watcher.Errors:
+			}
+		}
+	}()
+
+	rc, ok := in.(io.ReadCloser)
+	if !ok {
+		rc = readCloser{in}
+	}
+
+	return s.glsp.RunIO(rc, writeCloser{out})
+}
+
+type readCloser struct{ io.Reader }
+
+func (readCloser) Close() error { return nil }
+
+type writeCloser struct{ io.Writer }
+
+func (writeCloser) Close() error { return nil }
+
+func (s *Server) initialize(ctx *glsp.Context, params *protocol.InitializeParams) (any, error) {
+	caps := protocol.ServerCapabilities{
+		TextDocumentSync: protocol.TextDocumentSyncKindFull,
+		CompletionProvider: &protocol.CompletionOptions{
+			TriggerCharacters: []string{"["},
+		},
+		DefinitionProvider:      true,
+		DocumentLinkProvider:    &protocol.DocumentLinkOptions{},
+		HoverProvider:           true,
+		WorkspaceSymbolProvider: true,
+		ExecuteCommandProvider: &protocol.ExecuteCommandOptions{
+			Commands: []string{"notes.list", "notes.new", "notes.tag.list"},
+		},
+	}
+	return protocol.InitializeResult{Capabilities: caps}, nil
+}
+
+// completion runs the text typed after `[[` through Backend.Search and offers each hit's
+// title. Search matches title, tags and body text; file names are not matched.
+func (s *Server) completion(ctx *glsp.Context, params *protocol.CompletionParams) (any, error) {
+	text := s.docs.get(params.TextDocument.URI)
+	prefix := wikiLinkPrefix(text, params.Position)
+	notes, err := s.backend.Search(prefix, 50)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]protocol.CompletionItem, 0, len(notes))
+	kind := protocol.CompletionItemKindFile
+	for _, n := range notes {
+		label := n.Title
+		items = append(items, protocol.CompletionItem{
+			Label:  label,
+			Kind:   &kind,
+			Detail: strPtr(n.Path),
+		})
+	}
+	return items, nil
+}
+
+func (s *Server) definition(ctx *glsp.Context, params *protocol.DefinitionParams) (any, error) {
+	target, ok := linkTargetAt(s.docs.get(params.TextDocument.URI), params.Position)
+	if !ok {
+		return nil, nil
+	}
+
+	note, ok, err := s.backend.Resolve(target)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return protocol.Location{
+		URI:   "file://" + note.Path,
+		Range: protocol.Range{},
+	}, nil
+}
+
+// documentLink surfaces every wiki-style and markdown link in the document that resolves
+// to a note, so editors can render them as clickable and navigate to them directly.
+func (s *Server) documentLink(ctx *glsp.Context, params *protocol.DocumentLinkParams) (any, error) {
+	text := s.docs.get(params.TextDocument.URI)
+
+	occurrences := links.Occurrences(text)
+	docLinks := make([]protocol.DocumentLink, 0, len(occurrences))
+	for _, occ := range occurrences {
+		note, ok, err := s.backend.Resolve(occ.Target)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		target := "file://" + note.Path
+		docLinks = append(docLinks, protocol.DocumentLink{
+			Range: protocol.Range{
+				Start: positionForOffset(text, occ.Start),
+				End:   positionForOffset(text, occ.End),
+			},
+			Target: &target,
+		})
+	}
+	return docLinks, nil
+}
+
+func (s *Server) hover(ctx *glsp.Context, params *protocol.HoverParams) (any, error) {
+	target, ok := linkTargetAt(s.docs.get(params.TextDocument.URI), params.Position)
+	if !ok {
+		return nil, nil
+	}
+
+	note, ok, err := s.backend.Resolve(target)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("**" + note.Title + "**\n\n")
+	if len(note.Tags) > 0 {
+		b.WriteString("Tags: " + strings.Join(note.Tags, ", ") + "\n\n")
+	}
+	b.WriteString("Created: " + note.Created.Format(time.RFC3339) + "\n\n")
+	b.WriteString("Modified: " + note.Modified.Format(time.RFC3339))
+
+	return protocol.Hover{
+		Contents: protocol.MarkupContent{Kind: protocol.MarkupKindMarkdown, Value: b.String()},
+	}, nil
+}
+
+func (s *Server) workspaceSymbol(ctx *glsp.Context, params *protocol.WorkspaceSymbolParams) (any, error) {
+	notes, err := s.backend.Search(params.Query, 200)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := protocol.SymbolKindFile
+	symbols := make([]protocol.SymbolInformation, 0, len(notes))
+	for _, n := range notes {
+		symbols = append(symbols, protocol.SymbolInformation{
+			Name: n.Title,
+			Kind: kind,
+			Location: protocol.Location{
+				URI: "file://" + n.Path,
+			},
+		})
+	}
+	return symbols, nil
+}
+
+func (s *Server) executeCommand(ctx *glsp.Context, params *protocol.ExecuteCommandParams) (any, error) {
+	switch params.Command {
+	case "notes.list":
+		return s.backend.Search("", 1000)
+	case "notes.tag.list":
+		return s.backend.ListTags()
+	case "notes.new":
+		category, title, err := newNoteArgs(params.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		return s.backend.NewNote(category, title)
+	default:
+		return nil, errors.Errorf("Unknown command %q", params.Command)
+	}
+}
+
+func newNoteArgs(args []any) (category, title string, err error) {
+	if len(args) != 2 {
+		return "", "", errors.Errorf("notes.new expects [category, title] arguments but got %v", args)
+	}
+	category, ok1 := args[0].(string)
+	title, ok2 := args[1].(string)
+	if !ok1 || !ok2 {
+		return "", "", errors.Errorf("notes.new expects string arguments but got %v", args)
+	}
+	return category, title, nil
+}
+
+// wikiLinkPrefix extracts the partial text typed after the most recent unclosed `[[` on
+// the line pos is on, so completion can match it against note titles. It returns "" when
+// pos isn't inside an open `[[...` (no `[[` yet, or it's already been closed with `]]`).
+func wikiLinkPrefix(text string, pos protocol.Position) string {
+	before := lineAt(text, pos.Line)
+	if col := int(pos.Character); col < len(before) {
+		before = before[:col]
+	}
+
+	open := strings.LastIndex(before, "[[")
+	if open == -1 {
+		return ""
+	}
+
+	prefix := before[open+2:]
+	if strings.Contains(prefix, "]]") {
+		return ""
+	}
+	return prefix
+}
+
+// linkTargetAt returns the wiki-style or markdown link target found at pos in text, as
+// used by textDocument/definition and textDocument/hover to resolve the link under the
+// cursor rather than the document itself.
+func linkTargetAt(text string, pos protocol.Position) (string, bool) {
+	offset := offsetForPosition(text, pos)
+	for _, occ := range links.Occurrences(text) {
+		if offset >= occ.Start && offset < occ.End {
+			return occ.Target, true
+		}
+	}
+	return "", false
+}
+
+// lineAt returns the nth line of text (without its trailing newline), or "" when n is
+// past the end of text.
+func lineAt(text string, n uint32) string {
+	lines := strings.Split(text, "\n")
+	if int(n) >= len(lines) {
+		return ""
+	}
+	return strings.TrimSuffix(lines[n], "\r")
+}
+
+// offsetForPosition converts an LSP line/character position into a byte offset into text.
+func offsetForPosition(text string, pos protocol.Position) int {
+	lines := strings.SplitAfter(text, "\n")
+
+	offset := 0
+	for i := 0; i < int(pos.Line) && i < len(lines); i++ {
+		offset += len(lines[i])
+	}
+
+	if int(pos.Line) >= len(lines) {
+		return offset
+	}
+
+	line := strings.TrimSuffix(strings.TrimSuffix(lines[pos.Line], "\n"), "\r")
+	col := int(pos.Character)
+	if col > len(line) {
+		col = len(line)
+	}
+	return offset + col
+}
+
+// positionForOffset converts a byte offset into text back into an LSP line/character
+// position, counting characters as runes. It is the inverse of offsetForPosition, which
+// counts bytes, only for ASCII text.
+func positionForOffset(text string, offset int) protocol.Position {
+	var line, col uint32
+	for i, r := range text {
+		if i >= offset {
+			break
+		}
+		if r == '\n' {
+			line++
+			col = 0
+			continue
+		}
+		col++
+	}
+	return protocol.Position{Line: line, Character: col}
+}
+
+func strPtr(s string) *string { return &s }
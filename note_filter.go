@@ -0,0 +1,192 @@
+package notes
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// NoteFilter is a single composable predicate used by FindCmd to narrow results down by a
+// specific axis (tag, category, created/modified date, ...). Filters are combined with AND
+// semantics: a note must satisfy every configured filter to appear in the results.
+type NoteFilter interface {
+	Match(note *Note) bool
+}
+
+// andFilters combines filters with AND semantics. An empty set matches everything.
+type andFilters []NoteFilter
+
+func (fs andFilters) Match(note *Note) bool {
+	for _, f := range fs {
+		if !f.Match(note) {
+			return false
+		}
+	}
+	return true
+}
+
+func filterNotes(notes []*Note, filter NoteFilter) []*Note {
+	if filter == nil {
+		return notes
+	}
+
+	filtered := make([]*Note, 0, len(notes))
+	for _, n := range notes {
+		if filter.Match(n) {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered
+}
+
+// allTagsFilter requires a note to carry every one of Tags (normalized), e.g. repeated
+// `--tag foo --tag bar`.
+type allTagsFilter struct{ Tags []string }
+
+func (f allTagsFilter) Match(note *Note) bool {
+	have := normalizedTagSet(note.Tags)
+	for _, t := range f.Tags {
+		if !have[normalizeTag(t)] {
+			return false
+		}
+	}
+	return true
+}
+
+// anyTagFilter requires a note to carry at least one of Tags (normalized), e.g.
+// `--any-tag foo,bar`.
+type anyTagFilter struct{ Tags []string }
+
+func (f anyTagFilter) Match(note *Note) bool {
+	have := normalizedTagSet(note.Tags)
+	for _, t := range f.Tags {
+		if have[normalizeTag(t)] {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizedTagSet(tags []string) map[string]bool {
+	set := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		set[normalizeTag(t)] = true
+	}
+	return set
+}
+
+// categoryFilter requires a note's category to equal, or be nested under, Category, e.g.
+// `--category blog/tech` matches both "blog/tech" and "blog/tech/golang".
+type categoryFilter struct{ Category string }
+
+func (f categoryFilter) Match(note *Note) bool {
+	return note.Category == f.Category || strings.HasPrefix(note.Category, f.Category+"/")
+}
+
+// dateRangeFilter matches notes whose timestamp (returned by Field) falls in [From, To);
+// a zero bound leaves that side unbounded.
+type dateRangeFilter struct {
+	Field    func(*Note) time.Time
+	From, To time.Time
+}
+
+func (f dateRangeFilter) Match(note *Note) bool {
+	t := f.Field(note)
+	if !f.From.IsZero() && t.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !t.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+// parseDateRange parses a --created/--modified range spec: either an absolute range
+// "2024-01-01..2024-06-30" (either side may be omitted, and each side accepts 'YYYY',
+// 'YYYY-MM' or 'YYYY-MM-DD' precision), or a relative "<7d"/"<2w"/"<3h" meaning "within
+// the last N hours/days/weeks".
+func parseDateRange(spec string, now time.Time) (from, to time.Time, err error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return time.Time{}, time.Time{}, nil
+	}
+
+	if strings.HasPrefix(spec, "<") {
+		d, err := parseRelativeDuration(spec[1:])
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		return now.Add(-d), time.Time{}, nil
+	}
+
+	parts := strings.SplitN(spec, "..", 2)
+	if len(parts) != 2 {
+		return time.Time{}, time.Time{}, errors.Errorf("Invalid date range %q: expected 'FROM..TO' or '<DURATION'", spec)
+	}
+
+	if from, err = parseFlexibleDate(parts[0], false); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if to, err = parseFlexibleDate(parts[1], true); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return from, to, nil
+}
+
+// parseFlexibleDate parses "", "2024", "2024-01" or "2024-01-02" into a timestamp. An
+// empty string means unbounded. When end is true, the returned timestamp is bumped to the
+// start of the period *after* the one given, so e.g. "..2024-06" means "up to, but not
+// including, July 2024".
+func parseFlexibleDate(s string, end bool) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		if end {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01", s); err == nil {
+		if end {
+			t = t.AddDate(0, 1, 0)
+		}
+		return t, nil
+	}
+	if t, err := time.Parse("2006", s); err == nil {
+		if end {
+			t = t.AddDate(1, 0, 0)
+		}
+		return t, nil
+	}
+
+	return time.Time{}, errors.Errorf("Cannot parse date %q: expected 'YYYY', 'YYYY-MM' or 'YYYY-MM-DD'", s)
+}
+
+func parseRelativeDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("Empty relative duration: expected e.g. '7d', '2w', '3h'")
+	}
+
+	unit := s[len(s)-1]
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil {
+		return 0, errors.Errorf("Cannot parse relative duration %q: expected e.g. '7d', '2w', '3h'", s)
+	}
+
+	switch unit {
+	case 'h':
+		return time.Duration(n) * time.Hour, nil
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, nil
+	case 'w':
+		return time.Duration(n) * 7 * 24 * time.Hour, nil
+	default:
+		return 0, errors.Errorf("Unknown duration unit %q in %q: expected 'h', 'd' or 'w'", string(unit), s)
+	}
+}
@@ -0,0 +1,107 @@
+package notes
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateRangeAbsolute(t *testing.T) {
+	from, to, err := parseDateRange("2024-01-01..2024-06-30", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !from.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected from: %v", from)
+	}
+	if !to.Equal(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected to (exclusive day after 06-30): %v", to)
+	}
+}
+
+func TestParseDateRangeOpenEnded(t *testing.T) {
+	from, to, err := parseDateRange("2024-01..", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !from.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected from: %v", from)
+	}
+	if !to.IsZero() {
+		t.Fatalf("expected unbounded to but have %v", to)
+	}
+}
+
+func TestParseDateRangeRelative(t *testing.T) {
+	now := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	from, to, err := parseDateRange("<7d", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := now.Add(-7 * 24 * time.Hour); !from.Equal(want) {
+		t.Fatalf("want from %v but have %v", want, from)
+	}
+	if !to.IsZero() {
+		t.Fatalf("expected unbounded to but have %v", to)
+	}
+}
+
+func TestParseDateRangeInvalid(t *testing.T) {
+	if _, _, err := parseDateRange("not-a-range", time.Time{}); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestDateRangeFilterBounds(t *testing.T) {
+	f := dateRangeFilter{
+		Field: func(n *Note) time.Time { return n.Created },
+		From:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:    time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	inRange := &Note{Created: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)}
+	tooEarly := &Note{Created: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)}
+	tooLate := &Note{Created: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}
+
+	if !f.Match(inRange) {
+		t.Fatal("expected in-range note to match")
+	}
+	if f.Match(tooEarly) {
+		t.Fatal("expected note before range to not match")
+	}
+	if f.Match(tooLate) {
+		t.Fatal("expected note on the exclusive upper bound to not match")
+	}
+}
+
+func TestAllTagsFilterRequiresEveryTag(t *testing.T) {
+	f := allTagsFilter{Tags: []string{"golang", "cli"}}
+	if !f.Match(&Note{Tags: []string{"Golang", "CLI", "other"}}) {
+		t.Fatal("expected case-insensitive match on all tags")
+	}
+	if f.Match(&Note{Tags: []string{"golang"}}) {
+		t.Fatal("expected no match when one required tag is missing")
+	}
+}
+
+func TestAnyTagFilterRequiresOneTag(t *testing.T) {
+	f := anyTagFilter{Tags: []string{"golang", "rust"}}
+	if !f.Match(&Note{Tags: []string{"rust"}}) {
+		t.Fatal("expected match on any one of the tags")
+	}
+	if f.Match(&Note{Tags: []string{"python"}}) {
+		t.Fatal("expected no match when none of the tags are present")
+	}
+}
+
+func TestCategoryFilterMatchesNestedCategories(t *testing.T) {
+	f := categoryFilter{Category: "blog/tech"}
+	if !f.Match(&Note{Category: "blog/tech"}) {
+		t.Fatal("expected exact category to match")
+	}
+	if !f.Match(&Note{Category: "blog/tech/golang"}) {
+		t.Fatal("expected nested category to match")
+	}
+	if f.Match(&Note{Category: "blog/techsupport"}) {
+		t.Fatal("expected a same-prefix sibling category to not match")
+	}
+}
@@ -0,0 +1,52 @@
+package notes
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// stripFrontMatter removes a note's leading YAML (---) or TOML (+++) front-matter block,
+// if present, returning just the body below it. Front-matter carries metadata (created,
+// modified, tags, category) that free-text search should not match against.
+func stripFrontMatter(raw string) string {
+	lines := strings.SplitAfter(raw, "\n")
+	if len(lines) == 0 {
+		return raw
+	}
+
+	fence := strings.TrimSpace(lines[0])
+	if fence != "---" && fence != "+++" {
+		return raw
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == fence {
+			return strings.Join(lines[i+1:], "")
+		}
+	}
+	return raw
+}
+
+// noteBody reads the rendered body of the note at fullPath, with any front-matter block
+// stripped off the top. It is what both the SQLite index and the full-scan fallback
+// index as a note's body, so the two paths treat free text the same way.
+func noteBody(fullPath string) (string, error) {
+	raw, err := ioutil.ReadFile(fullPath)
+	if err != nil {
+		return "", errors.Wrapf(err, "Cannot read note %q", fullPath)
+	}
+	return stripFrontMatter(string(raw)), nil
+}
+
+// noteSearchText is the free-text corpus scanForQuery matches a query against: title,
+// tags and body, explicitly excluding metadata like created, modified and category so a
+// bare date string can't match a note purely because it appears in front-matter.
+func noteSearchText(note *Note) (string, error) {
+	body, err := noteBody(note.FilePath())
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(append([]string{note.Title}, note.Tags...), "\n") + "\n" + body, nil
+}
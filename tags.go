@@ -0,0 +1,129 @@
+package notes
+
+import (
+	"sort"
+	"strings"
+)
+
+// TagCount is how often a tag appears across the notes tree, and which notes use it.
+type TagCount struct {
+	Name  string   `json:"name"`
+	Count int      `json:"count"`
+	Notes []string `json:"notes"`
+}
+
+// CollectTags walks every note under cfg and aggregates how often each normalized tag is
+// used. TagListCmd lists its result. FindCmd's --tag/--any-tag filters do not call it; they
+// apply the same normalizeTag normalization to each note's own tags.
+func CollectTags(cfg *Config) ([]TagCount, error) {
+	cats, err := CollectCategories(cfg, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	order := make([]string, 0)
+	counts := make(map[string]*TagCount)
+
+	for _, cat := range cats {
+		for _, p := range cat.NotePaths {
+			note, err := LoadNote(p, cfg)
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range note.Tags {
+				name := normalizeTag(t)
+				if name == "" {
+					continue
+				}
+				tc, ok := counts[name]
+				if !ok {
+					tc = &TagCount{Name: name}
+					counts[name] = tc
+					order = append(order, name)
+				}
+				tc.Count++
+				tc.Notes = append(tc.Notes, p)
+			}
+		}
+	}
+
+	tags := make([]TagCount, 0, len(order))
+	for _, name := range order {
+		tags = append(tags, *counts[name])
+	}
+	return tags, nil
+}
+
+// CoOccurringTags returns every tag that appears alongside tag (on the same note),
+// together with how many notes pair them, sorted by that count descending. It is useful
+// for discovering related topic clusters.
+func CoOccurringTags(cfg *Config, tag string) ([]TagCount, error) {
+	target := normalizeTag(tag)
+
+	cats, err := CollectCategories(cfg, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	order := make([]string, 0)
+	counts := make(map[string]*TagCount)
+
+	for _, cat := range cats {
+		for _, p := range cat.NotePaths {
+			note, err := LoadNote(p, cfg)
+			if err != nil {
+				return nil, err
+			}
+
+			hasTarget := false
+			for _, t := range note.Tags {
+				if normalizeTag(t) == target {
+					hasTarget = true
+					break
+				}
+			}
+			if !hasTarget {
+				continue
+			}
+
+			for _, t := range note.Tags {
+				name := normalizeTag(t)
+				if name == "" || name == target {
+					continue
+				}
+				tc, ok := counts[name]
+				if !ok {
+					tc = &TagCount{Name: name}
+					counts[name] = tc
+					order = append(order, name)
+				}
+				tc.Count++
+				tc.Notes = append(tc.Notes, p)
+			}
+		}
+	}
+
+	tags := make([]TagCount, 0, len(order))
+	for _, name := range order {
+		tags = append(tags, *counts[name])
+	}
+	sortTagCountsByCount(tags)
+	return tags, nil
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+func sortTagCountsByCount(tags []TagCount) {
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Name < tags[j].Name
+	})
+}
+
+func sortTagCountsByName(tags []TagCount) {
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+}
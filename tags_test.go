@@ -0,0 +1,56 @@
+package notes
+
+import "testing"
+
+func TestCollectTagsNormalizesNames(t *testing.T) {
+	cfg := testNewConfigForListCmd("normal")
+	tags, err := CollectTags(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, tc := range tags {
+		if tc.Name != normalizeTag(tc.Name) {
+			t.Fatalf("tag %q is not normalized", tc.Name)
+		}
+		if tc.Name == "a-bit-long" {
+			found = true
+			if tc.Count < 1 {
+				t.Fatalf("expected count >= 1 for tag 'a-bit-long' but have %d", tc.Count)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected tag 'a-bit-long' to be collected from fixture notes")
+	}
+}
+
+func TestCoOccurringTagsExcludesTarget(t *testing.T) {
+	cfg := testNewConfigForListCmd("normal")
+	tags, err := CoOccurringTags(cfg, "A-Bit-Long")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range tags {
+		if tc.Name == "a-bit-long" {
+			t.Fatal("co-occurring tags should not include the queried tag itself")
+		}
+	}
+}
+
+func TestSortTagCountsByCountBreaksTiesByName(t *testing.T) {
+	tags := []TagCount{
+		{Name: "zebra", Count: 2},
+		{Name: "apple", Count: 2},
+		{Name: "mango", Count: 5},
+	}
+	sortTagCountsByCount(tags)
+
+	want := []string{"mango", "apple", "zebra"}
+	for i, name := range want {
+		if tags[i].Name != name {
+			t.Fatalf("expected order %v but have %v", want, tags)
+		}
+	}
+}